@@ -0,0 +1,167 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestBackend_bearerTokenFileMutuallyExclusive(t *testing.T) {
+	tokenFile, err := ioutil.TempFile("", "http-backend-bearer-token")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(tokenFile.Name())
+	tokenFile.Close()
+
+	b := New()
+	conf := backendTestConfig(t, map[string]interface{}{
+		"address":           "https://example.com/state",
+		"bearer_token":      "a-token",
+		"bearer_token_file": tokenFile.Name(),
+	})
+
+	if err := b.Configure(conf); err == nil {
+		t.Fatal("expected an error when both bearer_token and bearer_token_file are set")
+	}
+}
+
+func TestBackend_authHeaderNameRequiresValue(t *testing.T) {
+	b := New()
+	conf := backendTestConfig(t, map[string]interface{}{
+		"address":          "https://example.com/state",
+		"auth_header_name": "X-Vault-Token",
+	})
+
+	if err := b.Configure(conf); err == nil {
+		t.Fatal("expected an error when auth_header_name is set without auth_header_value")
+	}
+}
+
+func TestBuildRequestDecorator_compositionOrder(t *testing.T) {
+	b := &Backend{
+		bearerToken:     "a-token",
+		authHeaderName:  "X-Extra",
+		authHeaderValue: "extra-value",
+	}
+
+	decorate := b.buildRequestDecorator()
+	if decorate == nil {
+		t.Fatal("expected a non-nil decorator when bearerToken and authHeaderName are set")
+	}
+
+	req, err := http.NewRequest("GET", "https://example.com/state", nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := decorate(req); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if got := req.Header.Get("Authorization"); got != "Bearer a-token" {
+		t.Fatalf("expected the bearer token decorator to set Authorization, got %q", got)
+	}
+	if got := req.Header.Get("X-Extra"); got != "extra-value" {
+		t.Fatalf("expected the auth_header_name decorator to set X-Extra, got %q", got)
+	}
+}
+
+func TestBuildRequestDecorator_nilWhenUnconfigured(t *testing.T) {
+	b := &Backend{}
+	if decorate := b.buildRequestDecorator(); decorate != nil {
+		t.Fatal("expected a nil decorator when no auth mechanism is configured")
+	}
+}
+
+func TestForwardAuthConfig_decorateCopiesHeaders(t *testing.T) {
+	faServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Authorization", "Bearer from-forward-auth")
+		w.Header().Set("X-Not-Requested", "should-not-be-copied")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer faServer.Close()
+
+	fa := &forwardAuthConfig{
+		address: faServer.URL,
+		headers: []string{"Authorization"},
+		client:  faServer.Client(),
+	}
+
+	req, err := http.NewRequest("GET", "https://example.com/state", nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := fa.decorate(req); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if got := req.Header.Get("Authorization"); got != "Bearer from-forward-auth" {
+		t.Fatalf("expected the configured header to be copied from the forward-auth response, got %q", got)
+	}
+	if got := req.Header.Get("X-Not-Requested"); got != "" {
+		t.Fatalf("expected only the configured headers to be copied, got X-Not-Requested=%q", got)
+	}
+}
+
+func TestForwardAuthConfig_decorateFailsOnNonOK(t *testing.T) {
+	faServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer faServer.Close()
+
+	fa := &forwardAuthConfig{
+		address: faServer.URL,
+		headers: []string{"Authorization"},
+		client:  faServer.Client(),
+	}
+
+	req, err := http.NewRequest("GET", "https://example.com/state", nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := fa.decorate(req); err == nil {
+		t.Fatal("expected an error when the forward-auth endpoint does not return 200")
+	}
+}
+
+func TestBackend_forwardAuthSkipCertVerificationAndCAFileMutuallyExclusive(t *testing.T) {
+	caFile, err := ioutil.TempFile("", "http-backend-fa-ca")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(caFile.Name())
+	caFile.Close()
+
+	b := New()
+	conf := backendTestConfig(t, map[string]interface{}{
+		"address": "https://example.com/state",
+		"forward_auth": []interface{}{
+			map[string]interface{}{
+				"address":                "https://auth.example.com",
+				"skip_cert_verification": true,
+				"ca_file":                caFile.Name(),
+			},
+		},
+	})
+
+	if err := b.Configure(conf); err == nil {
+		t.Fatal("expected an error when forward_auth.skip_cert_verification and forward_auth.ca_file are both set")
+	}
+}
+
+// backendTestConfig mirrors backend.TestBackendConfig's config-building step
+// without the Configure() call, so tests can assert on the error returned
+// by Configure() directly instead of failing the test via t.Fatalf.
+func backendTestConfig(t *testing.T, c map[string]interface{}) *terraform.ResourceConfig {
+	t.Helper()
+	rc, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return terraform.NewResourceConfig(rc)
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/backend"
@@ -69,6 +70,73 @@ func New() backend.Backend {
 				Description: "(Optional) The password for HTTP basic authentication.",
 			},
 
+			"bearer_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_BEARER_TOKEN", nil),
+				Description: "(Optional) The bearer token to send in the Authorization header of every request.",
+			},
+
+			"bearer_token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_BEARER_TOKEN_FILE", nil),
+				Description: "(Optional) File containing the bearer token to send in the Authorization header of every request. Mutually exclusive with bearer_token.",
+			},
+
+			"auth_header_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_AUTH_HEADER_NAME", nil),
+				Description: "(Optional) Name of an arbitrary header to set on every request, e.g. X-Vault-Token. Requires auth_header_value.",
+			},
+
+			"auth_header_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_AUTH_HEADER_VALUE", nil),
+				Description: "(Optional) Value of the header named by auth_header_name.",
+			},
+
+			"forward_auth": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "(Optional) Delegate authentication to an external service: before each state operation, the address is queried and configured response headers are copied onto the outgoing request.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "(Required) The address of the forward-auth endpoint.",
+						},
+						"trust_forward_header": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "(Optional) Set X-Forwarded-Host on the forward-auth request, mirroring how external auth proxies forward the original host. Defaults to false.",
+						},
+						"headers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "(Optional) Response headers to copy from the forward-auth endpoint onto the outgoing request. Defaults to [\"Authorization\"].",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"skip_cert_verification": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "(Optional) Whether to skip TLS verification when calling the forward-auth endpoint. Defaults to false.",
+						},
+						"ca_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "(Optional) CA to use when the forward-auth endpoint uses a self signed certificate.",
+						},
+					},
+				},
+			},
+
 			"skip_cert_verification": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -82,6 +150,13 @@ func New() backend.Backend {
 				Description: "CA to use when the rest api is using a self signed certificate.",
 			},
 
+			"local_cert_ca_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_LOCAL_CERT_CA_PEM", nil),
+				Description: "CA, as a literal PEM string, to use when the rest api is using a self signed certificate. Mutually exclusive with local_cert_ca_file.",
+			},
+
 			"mutual_tls_authentication": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -95,17 +170,137 @@ func New() backend.Backend {
 				Description: "Cert file to be used for mutual tls authentication.",
 			},
 
+			"local_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_LOCAL_CERT_PEM", nil),
+				Description: "Cert, as a literal PEM string, to be used for mutual tls authentication. Mutually exclusive with local_cert_file.",
+			},
+
 			"local_key_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Key file to be used for mutual tls authentication.",
 			},
 
+			"local_key_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_LOCAL_KEY_PEM", nil),
+				Description: "Key, as a literal PEM string, to be used for mutual tls authentication. Mutually exclusive with local_key_file.",
+			},
+
 			"local_ca_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "CA to be used for mutual tls authentication.",
 			},
+
+			"local_ca_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_LOCAL_CA_PEM", nil),
+				Description: "CA, as a literal PEM string, to be used for mutual tls authentication. Mutually exclusive with local_ca_file.",
+			},
+
+			"local_pkcs12_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PKCS#12 bundle (cert, key and any chain certs) to be used for mutual tls authentication. Mutually exclusive with local_cert_file, local_key_file, and local_ca_file.",
+			},
+
+			"local_pkcs12_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Password to decrypt local_pkcs12_file. Mutually exclusive with local_pkcs12_password_file.",
+			},
+
+			"local_pkcs12_password_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File containing the password to decrypt local_pkcs12_file. Mutually exclusive with local_pkcs12_password.",
+			},
+
+			"tls_min_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "(Optional) The minimum TLS version to use. One of \"1.0\", \"1.1\", \"1.2\", or \"1.3\". Defaults to Go's default.",
+			},
+
+			"tls_max_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "(Optional) The maximum TLS version to use. One of \"1.0\", \"1.1\", \"1.2\", or \"1.3\". Defaults to Go's default.",
+			},
+
+			"tls_cipher_suites": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "(Optional) A list of cipher suites to use, by IANA name (e.g. \"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256\"). Defaults to Go's default suite list.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "(Optional) Override the server name used for SNI and certificate verification. Useful when address is an IP address.",
+			},
+
+			"tls_prefer_server_cipher_suites": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "(Optional) Whether to prefer the server's cipher suite ordering over the client's. Defaults to false.",
+			},
+
+			"client_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10,
+				Description: "(Optional) Timeout, in seconds, for state and lock/unlock HTTP requests. Defaults to 10.",
+			},
+
+			"tls_handshake_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10,
+				Description: "(Optional) Timeout, in seconds, for the TLS handshake portion of a request. Defaults to 10.",
+			},
+
+			"idle_conn_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     90,
+				Description: "(Optional) How long, in seconds, an idle connection is kept open in the pool before being closed. Defaults to 90.",
+			},
+
+			"enable_optimistic_locking": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_ENABLE_OPTIMISTIC_LOCKING", false),
+				Description: "(Optional) Send the ETag from the last state read as an If-Match header on state writes, and refuse to persist if the server reports a 412 Precondition Failed. Defaults to false.",
+			},
+
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_MAX_RETRIES", 2),
+				Description: "(Optional) The number of times to retry a request that comes back with a 423, 429, or 503 and a Retry-After header. Defaults to 2.",
+			},
+
+			"retry_wait_min": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_RETRY_WAIT_MIN", 1),
+				Description: "(Optional) The minimum time, in seconds, to wait before retrying a request when the server does not send a Retry-After header. Defaults to 1.",
+			},
+
+			"retry_wait_max": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_RETRY_WAIT_MAX", 30),
+				Description: "(Optional) The maximum time, in seconds, to wait between retries. Both the exponential backoff and any server-provided Retry-After are capped to this. Defaults to 30.",
+			},
 		},
 	}
 
@@ -132,9 +327,101 @@ type Backend struct {
 	localCertFile   string
 	localKeyFile    string
 	localCAFile     string
+	localPKCS12File string
 	mutualTLS       bool
 	username        string
 	password        string
+	bearerToken     string
+	authHeaderName  string
+	authHeaderValue string
+	forwardAuth     *forwardAuthConfig
+
+	clientTimeout       time.Duration
+	tlsHandshakeTimeout time.Duration
+	idleConnTimeout     time.Duration
+
+	enableOptimisticLocking bool
+	maxRetries              int
+	retryWaitMin            time.Duration
+	retryWaitMax            time.Duration
+}
+
+// forwardAuthConfig holds the settings needed to delegate auth to an
+// external service, mirroring the pattern of external auth proxies: a GET
+// is issued to address before every state operation, and the configured
+// response headers are copied onto the outgoing request.
+type forwardAuthConfig struct {
+	address            string
+	trustForwardHeader bool
+	headers            []string
+	client             *http.Client
+}
+
+func (f *forwardAuthConfig) decorate(req *http.Request) error {
+	faReq, err := http.NewRequest("GET", f.address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build forward_auth request: %s", err)
+	}
+	if f.trustForwardHeader {
+		faReq.Header.Set("X-Forwarded-Host", req.URL.Host)
+	}
+
+	resp, err := f.client.Do(faReq)
+	if err != nil {
+		return fmt.Errorf("failed to call forward_auth address %s: %s", f.address, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("forward_auth address %s returned %s", f.address, resp.Status)
+	}
+
+	for _, h := range f.headers {
+		if v := resp.Header.Get(h); v != "" {
+			req.Header.Set(h, v)
+		}
+	}
+	return nil
+}
+
+// buildRequestDecorator composes the bearer token, static header, and
+// forward_auth mechanisms into a single decorator so they all layer on top
+// of the basic auth and mutual-TLS paths already handled by RemoteClient.
+func (b *Backend) buildRequestDecorator() func(req *http.Request) error {
+	var decorators []func(req *http.Request) error
+
+	if b.bearerToken != "" {
+		token := b.bearerToken
+		decorators = append(decorators, func(req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		})
+	}
+
+	if b.authHeaderName != "" {
+		name, value := b.authHeaderName, b.authHeaderValue
+		decorators = append(decorators, func(req *http.Request) error {
+			req.Header.Set(name, value)
+			return nil
+		})
+	}
+
+	if b.forwardAuth != nil {
+		decorators = append(decorators, b.forwardAuth.decorate)
+	}
+
+	if len(decorators) == 0 {
+		return nil
+	}
+
+	return func(req *http.Request) error {
+		for _, d := range decorators {
+			if err := d(req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 }
 
 func (b *Backend) configure(ctx context.Context) error {
@@ -185,6 +472,15 @@ func (b *Backend) configure(ctx context.Context) error {
 
 	b.unlockMethod = data.Get("unlock_method").(string)
 
+	b.clientTimeout = time.Duration(data.Get("client_timeout").(int)) * time.Second
+	b.tlsHandshakeTimeout = time.Duration(data.Get("tls_handshake_timeout").(int)) * time.Second
+	b.idleConnTimeout = time.Duration(data.Get("idle_conn_timeout").(int)) * time.Second
+
+	b.enableOptimisticLocking = data.Get("enable_optimistic_locking").(bool)
+	b.maxRetries = data.Get("max_retries").(int)
+	b.retryWaitMin = time.Duration(data.Get("retry_wait_min").(int)) * time.Second
+	b.retryWaitMax = time.Duration(data.Get("retry_wait_max").(int)) * time.Second
+
 	if v, ok := data.GetOk("username"); ok {
 		b.username = v.(string)
 	}
@@ -193,8 +489,88 @@ func (b *Backend) configure(ctx context.Context) error {
 		b.password = v.(string)
 	}
 
+	if v, ok := data.GetOk("bearer_token"); ok {
+		b.bearerToken = v.(string)
+	}
+
+	if v, ok := data.GetOk("bearer_token_file"); ok {
+		if b.bearerToken != "" {
+			return fmt.Errorf("bearer_token and bearer_token_file are mutually exclusive")
+		}
+		tokenFile := v.(string)
+		content, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read %s into memory: %s", tokenFile, err)
+		}
+		b.bearerToken = strings.TrimSpace(string(content))
+	}
+
+	if v, ok := data.GetOk("auth_header_name"); ok {
+		b.authHeaderName = v.(string)
+		value, ok := data.GetOk("auth_header_value")
+		if !ok {
+			return fmt.Errorf("auth_header_name is set but auth_header_value is not")
+		}
+		b.authHeaderValue = value.(string)
+	}
+
+	if v, ok := data.GetOk("forward_auth"); ok {
+		list := v.([]interface{})
+		if len(list) == 1 && list[0] != nil {
+			fa := list[0].(map[string]interface{})
+
+			faAddress := fa["address"].(string)
+			if validationErr := assertValidURL(faAddress); validationErr != nil {
+				return validationErr
+			}
+
+			headers := []string{"Authorization"}
+			if hv, ok := fa["headers"].([]interface{}); ok && len(hv) > 0 {
+				headers = make([]string, 0, len(hv))
+				for _, h := range hv {
+					headers = append(headers, h.(string))
+				}
+			}
+
+			faSkipTLS := fa["skip_cert_verification"].(bool)
+			faCAFile, _ := fa["ca_file"].(string)
+			if faSkipTLS && faCAFile != "" {
+				return fmt.Errorf("forward_auth.skip_cert_verification is true and forward_auth.ca_file is set. please choose one or the other")
+			}
+
+			faClient := &http.Client{Timeout: time.Second * 10}
+			if faSkipTLS {
+				faClient.Transport = &http.Transport{
+					TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+				}
+			} else if caFile := faCAFile; caFile != "" {
+				rootCAs, _ := x509.SystemCertPool()
+				if rootCAs == nil {
+					rootCAs = x509.NewCertPool()
+				}
+				cert, err := ioutil.ReadFile(caFile)
+				if err != nil {
+					return fmt.Errorf("Failed to read %s into memory: %s", caFile, err)
+				}
+				if ok := rootCAs.AppendCertsFromPEM(cert); !ok {
+					return fmt.Errorf("No certs could be appended: %s", cert)
+				}
+				faClient.Transport = &http.Transport{
+					TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+				}
+			}
+
+			b.forwardAuth = &forwardAuthConfig{
+				address:            faAddress,
+				trustForwardHeader: fa["trust_forward_header"].(bool),
+				headers:            headers,
+				client:             faClient,
+			}
+		}
+	}
+
 	client := &http.Client{
-		Timeout: time.Second * 10,
+		Timeout: b.clientTimeout,
 	}
 
 	if v, ok := data.GetOk("skip_cert_verification"); ok {
@@ -204,61 +580,67 @@ func (b *Backend) configure(ctx context.Context) error {
 			if !isHTTPS(addressURL) {
 				return fmt.Errorf("Address must be of type HTTPS if skip_cert_verification = true")
 			}
-			// If local_cert_ca_file is also set, raise an error
+			// If local_cert_ca_file or local_cert_ca_pem is also set, raise an error
 			if v, ok := data.GetOk("local_cert_ca_file"); ok {
 				return fmt.Errorf("skip_cert_verification is %t and local_cert_ca_file is set: %s. please choose one or the other", b.skipTLS, v)
 			}
+			if _, ok := data.GetOk("local_cert_ca_pem"); ok {
+				return fmt.Errorf("skip_cert_verification is true and local_cert_ca_pem is set. please choose one or the other")
+			}
 			// If mutual_tls_authentication is also set, raise an error
 			if data.Get("mutual_tls_authentication").(bool) == true {
 				return fmt.Errorf("skip_cert_verification is true and mutual_tls_authentication is set. please choose one or the other")
 			}
 			// Replace the client with one that ignores TLS verification
+			tlsConfig, err := baseTLSConfig(data)
+			if err != nil {
+				return err
+			}
+			tlsConfig.InsecureSkipVerify = true
+			key := b.newTransportCacheKey(data, "skip", credentialSource{}, credentialSource{}, credentialSource{})
 			client = &http.Client{
-				Timeout: time.Second * 10,
-				Transport: &http.Transport{
-					TLSClientConfig: &tls.Config{
-						InsecureSkipVerify: true,
-					},
-				},
+				Timeout:   b.clientTimeout,
+				Transport: b.cachedTransport(key, tlsConfig),
 			}
 		}
 	}
 
-	if v, ok := data.GetOk("local_cert_ca_file"); ok {
-		// If local_cert_ca_file exists, the address must be of type HTTPS
+	caSource, caErr := resolvePEMSource(data, "local_cert_ca_file", "local_cert_ca_pem")
+	if caErr != nil {
+		return caErr
+	}
+	if len(caSource.data) > 0 {
+		// If local_cert_ca_file/local_cert_ca_pem is set, the address must be of type HTTPS
 		if !isHTTPS(addressURL) {
-			return fmt.Errorf("Address must be of type HTTPS if local_cert_ca_file is set")
+			return fmt.Errorf("Address must be of type HTTPS if local_cert_ca_file or local_cert_ca_pem is set")
 		}
 		if data.Get("mutual_tls_authentication").(bool) == true {
-			return fmt.Errorf("mutual_tls_authentication is true and local_cert_ca_file is set. Please choose one or the other")
+			return fmt.Errorf("mutual_tls_authentication is true and local_cert_ca_file/local_cert_ca_pem is set. Please choose one or the other")
 		}
 		if data.Get("skip_cert_verification").(bool) == true {
-			return fmt.Errorf("skip_cert_verification is true and local_cert_ca_file is set. Please choose one or the other")
+			return fmt.Errorf("skip_cert_verification is true and local_cert_ca_file/local_cert_ca_pem is set. Please choose one or the other")
 		}
-		b.localCertCAFile = v.(string)
+		b.localCertCAFile = caSource.cred.path
 
 		// Get the SystemCertPool, continue with an empty pool on error
 		rootCAs, _ := x509.SystemCertPool()
 		if rootCAs == nil {
 			rootCAs = x509.NewCertPool()
 		}
-		// Read in the ca cert file
-		cert, err := ioutil.ReadFile(b.localCertCAFile)
-		if err != nil {
-			return fmt.Errorf("Failed to read %s into memory: %s", b.localCertCAFile, err)
-		}
 		// Append our cert to the system pool
-		if ok := rootCAs.AppendCertsFromPEM(cert); !ok {
-			return fmt.Errorf("No certs could be appended: %s", cert)
+		if ok := rootCAs.AppendCertsFromPEM(caSource.data); !ok {
+			return fmt.Errorf("No certs could be appended: %s", caSource.data)
 		}
 		// Replace the client with one that contains the CA.
+		tlsConfig, err := baseTLSConfig(data)
+		if err != nil {
+			return err
+		}
+		tlsConfig.RootCAs = rootCAs
+		key := b.newTransportCacheKey(data, "ca", caSource.cred, credentialSource{}, credentialSource{})
 		client = &http.Client{
-			Timeout: time.Second * 10,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					RootCAs: rootCAs,
-				},
-			},
+			Timeout:   b.clientTimeout,
+			Transport: b.cachedTransport(key, tlsConfig),
 		}
 	}
 
@@ -270,60 +652,150 @@ func (b *Backend) configure(ctx context.Context) error {
 			if !isHTTPS(addressURL) {
 				return fmt.Errorf("Address must be of type HTTPS if mutual_tls_authentication = true")
 			}
-			// If mutual_tls_authentication = true, the local_cert_file needs to be set.
-			if v, ok := data.GetOk("local_cert_file"); ok {
-				b.localCertFile = v.(string)
-			} else {
-				return fmt.Errorf("mutual_tls_authentication is true and local_cert_file is not set %s", b.localCertFile)
-			}
-			// If mutual_tls_authentication = true, the local_key_file needs to be set.
-			if v, ok := data.GetOk("local_key_file"); ok {
-				b.localKeyFile = v.(string)
-			} else {
-				return fmt.Errorf("mutual_tls_authentication is true and local_key_file is not set %s", b.localKeyFile)
+			_, hasPKCS12 := data.GetOk("local_pkcs12_file")
+			certSource, err := resolvePEMSource(data, "local_cert_file", "local_cert_pem")
+			if err != nil {
+				return err
 			}
-			// If mutual_tls_authentication = true, the local_ca_file needs to be set.
-			if v, ok := data.GetOk("local_ca_file"); ok {
-				b.localCAFile = v.(string)
-			} else {
-				return fmt.Errorf("mutual_tls_authentication is true and local_ca_file is not set %s", b.localCAFile)
+			keySource, err := resolvePEMSource(data, "local_key_file", "local_key_pem")
+			if err != nil {
+				return err
 			}
-			// load client cert
-			certs, err := tls.LoadX509KeyPair(b.localCertFile, b.localKeyFile)
+			caSource, err := resolvePEMSource(data, "local_ca_file", "local_ca_pem")
 			if err != nil {
-				return fmt.Errorf("Can not load pem files: %s and : %s. Error: %s", b.localCertFile, b.localKeyFile, err)
+				return err
 			}
-			// Get the SystemCertPool, continue with an empty pool on error
-			rootCAs, _ := x509.SystemCertPool()
-			if rootCAs == nil {
-				rootCAs = x509.NewCertPool()
+			hasCert := len(certSource.data) > 0
+			hasKey := len(keySource.data) > 0
+			hasCA := len(caSource.data) > 0
+
+			if hasPKCS12 && (hasCert || hasKey || hasCA) {
+				return fmt.Errorf("local_pkcs12_file is mutually exclusive with local_cert_file, local_cert_pem, local_key_file, local_key_pem, local_ca_file, and local_ca_pem")
+			}
+
+			var certs tls.Certificate
+			var rootCAs *x509.CertPool
+
+			if hasPKCS12 {
+				b.localPKCS12File = data.Get("local_pkcs12_file").(string)
+
+				password, err := b.pkcs12Password(data)
+				if err != nil {
+					return err
+				}
+
+				pfxData, err := ioutil.ReadFile(b.localPKCS12File)
+				if err != nil {
+					return fmt.Errorf("Failed to read %s into memory: %s", b.localPKCS12File, err)
+				}
+
+				certs, rootCAs, err = loadPKCS12Bundle(pfxData, password)
+				if err != nil {
+					return fmt.Errorf("Can not load pkcs12 bundle %s. Error: %s", b.localPKCS12File, err)
+				}
+			} else {
+				// If mutual_tls_authentication = true, local_cert_file/local_cert_pem needs to be set.
+				if !hasCert {
+					return fmt.Errorf("mutual_tls_authentication is true and neither local_cert_file nor local_cert_pem is set")
+				}
+				// If mutual_tls_authentication = true, local_key_file/local_key_pem needs to be set.
+				if !hasKey {
+					return fmt.Errorf("mutual_tls_authentication is true and neither local_key_file nor local_key_pem is set")
+				}
+				// If mutual_tls_authentication = true, local_ca_file/local_ca_pem needs to be set.
+				if !hasCA {
+					return fmt.Errorf("mutual_tls_authentication is true and neither local_ca_file nor local_ca_pem is set")
+				}
+				b.localCertFile = certSource.cred.path
+				b.localKeyFile = keySource.cred.path
+				b.localCAFile = caSource.cred.path
+
+				// load client cert
+				certs, err = tls.X509KeyPair(certSource.data, keySource.data)
+				if err != nil {
+					return fmt.Errorf("Can not load pem files: %s and : %s. Error: %s", b.localCertFile, b.localKeyFile, err)
+				}
+				// Get the SystemCertPool, continue with an empty pool on error
+				rootCAs, _ = x509.SystemCertPool()
+				if rootCAs == nil {
+					rootCAs = x509.NewCertPool()
+				}
+				// Append our cert to the system pool
+				if ok := rootCAs.AppendCertsFromPEM(caSource.data); !ok {
+					return fmt.Errorf("No certs could be appended: %s", caSource.data)
+				}
 			}
-			// Read in the ca cert file
-			cert, err := ioutil.ReadFile(b.localCAFile)
+
+			// Replace the client with one that contains the certs.
+			tlsConfig, err := baseTLSConfig(data)
 			if err != nil {
-				return fmt.Errorf("Failed to read %s into memory: %s", b.localCAFile, err)
+				return err
 			}
-			// Append our cert to the system pool
-			if ok := rootCAs.AppendCertsFromPEM(cert); !ok {
-				return fmt.Errorf("No certs could be appended: %s", cert)
+			tlsConfig.Certificates = []tls.Certificate{certs}
+			tlsConfig.RootCAs = rootCAs
+
+			var key transportCacheKey
+			if hasPKCS12 {
+				key = b.newTransportCacheKey(data, "mtls-pkcs12", credentialSource{path: b.localPKCS12File}, credentialSource{}, credentialSource{})
+			} else {
+				key = b.newTransportCacheKey(data, "mtls-pem", caSource.cred, certSource.cred, keySource.cred)
 			}
-			// Replace the client with one that contains the certs.
+
 			client = &http.Client{
-				Timeout: time.Second * 10,
-				Transport: &http.Transport{
-					TLSClientConfig: &tls.Config{
-						Certificates: []tls.Certificate{certs},
-						RootCAs:      rootCAs,
-					},
-				},
+				Timeout:   b.clientTimeout,
+				Transport: b.cachedTransport(key, tlsConfig),
 			}
 		}
 	}
 
+	// None of skip_cert_verification, local_cert_ca_file/local_cert_ca_pem, or
+	// mutual_tls_authentication were set, so client is still the bare-timeout
+	// default above with a nil Transport (falling back to
+	// http.DefaultTransport). Build one from baseTLSConfig so tls_min_version,
+	// tls_max_version, tls_cipher_suites, tls_server_name,
+	// tls_prefer_server_cipher_suites, tls_handshake_timeout, and
+	// idle_conn_timeout still take effect against an already-trusted endpoint.
+	if client.Transport == nil {
+		tlsConfig, err := baseTLSConfig(data)
+		if err != nil {
+			return err
+		}
+		key := b.newTransportCacheKey(data, "default", credentialSource{}, credentialSource{}, credentialSource{})
+		client = &http.Client{
+			Timeout:   b.clientTimeout,
+			Transport: b.cachedTransport(key, tlsConfig),
+		}
+	}
+
 	b.client = client
 	return nil
 }
 
+// pkcs12Password resolves local_pkcs12_password / local_pkcs12_password_file
+// into the literal password used to decrypt local_pkcs12_file.
+func (b *Backend) pkcs12Password(data *schema.ResourceData) (string, error) {
+	password, hasPassword := data.GetOk("local_pkcs12_password")
+	passwordFile, hasPasswordFile := data.GetOk("local_pkcs12_password_file")
+
+	if hasPassword && hasPasswordFile {
+		return "", fmt.Errorf("local_pkcs12_password and local_pkcs12_password_file are mutually exclusive")
+	}
+
+	if hasPasswordFile {
+		content, err := ioutil.ReadFile(passwordFile.(string))
+		if err != nil {
+			return "", fmt.Errorf("Failed to read %s into memory: %s", passwordFile.(string), err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	if hasPassword {
+		return password.(string), nil
+	}
+
+	return "", nil
+}
+
 func assertValidURL(addr string) error {
 	addre, err := url.ParseRequestURI(addr)
 	if err != nil {
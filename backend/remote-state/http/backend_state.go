@@ -23,10 +23,15 @@ const (
 func (b *Backend) States() ([]string, error) {
 	var result []string
 	client := &RemoteClient{
-		client:   b.client,
-		address:  b.address,
-		username: b.username,
-		password: b.password,
+		client:                  b.client,
+		address:                 b.address,
+		username:                b.username,
+		password:                b.password,
+		decorateRequest:         b.buildRequestDecorator(),
+		enableOptimisticLocking: b.enableOptimisticLocking,
+		maxRetries:              b.maxRetries,
+		retryWaitMin:            b.retryWaitMin,
+		retryWaitMax:            b.retryWaitMax,
 	}
 
 	resp, err := client.Get()
@@ -84,15 +89,20 @@ func (b *Backend) remoteClient(name string) (*RemoteClient, error) {
 		return nil, errors.New("missing state name")
 	}
 	client := &RemoteClient{
-		client:        b.client,
-		address:       b.statePath(name),
-		updateMethod:  b.updateMethod,
-		lockAddress:   b.lockPath(name),
-		unlockAddress: b.lockPath(name),
-		lockMethod:    b.lockMethod,
-		unlockMethod:  b.unlockMethod,
-		username:      b.username,
-		password:      b.password,
+		client:                  b.client,
+		address:                 b.statePath(name),
+		updateMethod:            b.updateMethod,
+		lockAddress:             b.lockPath(name),
+		unlockAddress:           b.lockPath(name),
+		lockMethod:              b.lockMethod,
+		unlockMethod:            b.unlockMethod,
+		username:                b.username,
+		password:                b.password,
+		decorateRequest:         b.buildRequestDecorator(),
+		enableOptimisticLocking: b.enableOptimisticLocking,
+		maxRetries:              b.maxRetries,
+		retryWaitMin:            b.retryWaitMin,
+		retryWaitMax:            b.retryWaitMax,
 	}
 	return client, nil
 }
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/backend"
+)
+
+func TestBackend_defaultTransportAppliesTLSConfig(t *testing.T) {
+	b := backend.TestBackendConfig(t, New(), map[string]interface{}{
+		"address":         "https://example.com/state",
+		"tls_min_version": "1.3",
+	}).(*Backend)
+
+	if b.client.Transport == nil {
+		t.Fatal("expected a non-nil Transport even when skip_cert_verification, local_cert_ca_file/_pem, and mutual_tls_authentication are all unset")
+	}
+
+	transport, ok := b.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", b.client.Transport)
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Fatal("expected tls_min_version to be applied to the default transport")
+	}
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/backend"
+)
+
+func TestBackend_defaultTransportAppliesTimeouts(t *testing.T) {
+	b := backend.TestBackendConfig(t, New(), map[string]interface{}{
+		"address":               "https://example.com/state",
+		"tls_handshake_timeout": 7,
+		"idle_conn_timeout":     11,
+	}).(*Backend)
+
+	transport, ok := b.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", b.client.Transport)
+	}
+	if transport.TLSHandshakeTimeout != 7*time.Second {
+		t.Fatalf("expected tls_handshake_timeout to be applied to the default transport, got %s", transport.TLSHandshakeTimeout)
+	}
+	if transport.IdleConnTimeout != 11*time.Second {
+		t.Fatalf("expected idle_conn_timeout to be applied to the default transport, got %s", transport.IdleConnTimeout)
+	}
+}
@@ -0,0 +1,270 @@
+package http
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+)
+
+// ErrStateChangedConcurrently is returned by Put when enable_optimistic_locking
+// is set and the server rejects the write with 412 Precondition Failed,
+// meaning the ETag recorded on the last Get no longer matches.
+var ErrStateChangedConcurrently = errors.New("state changed concurrently since last read; refusing to overwrite")
+
+// RemoteClient is a remote client that stores data in an HTTP REST endpoint.
+type RemoteClient struct {
+	client        *http.Client
+	address       string
+	updateMethod  string
+	lockAddress   string
+	lockMethod    string
+	unlockAddress string
+	unlockMethod  string
+	username      string
+	password      string
+	lockID        string
+	jsonLockInfo  []byte
+
+	// decorateRequest, when set, is invoked for every outgoing request
+	// before it is sent, giving auth mechanisms beyond basic auth a chance
+	// to set headers. It composes with username/password, which is still
+	// applied via req.SetBasicAuth below.
+	decorateRequest func(req *http.Request) error
+
+	// enableOptimisticLocking, when true, makes Get() record the ETag of
+	// the state it read and Put() send it back as If-Match, refusing to
+	// persist with ErrStateChangedConcurrently on a 412 response.
+	enableOptimisticLocking bool
+	etag                    string
+
+	// maxRetries bounds the number of retries httpRequest performs when
+	// the lock/unlock/state endpoints respond 423, 429, or 503.
+	// retryWaitMin/retryWaitMax bound the exponential backoff used when
+	// the response carries no Retry-After header.
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+}
+
+func (c *RemoteClient) httpRequest(method string, url string, data *[]byte, what string) (*http.Response, error) {
+	return c.httpRequestWithHeaders(method, url, data, what, nil)
+}
+
+func (c *RemoteClient) httpRequestWithHeaders(method string, url string, data *[]byte, what string, extraHeaders map[string]string) (*http.Response, error) {
+	// retry_wait_min defaults to 1 second at the schema level (see
+	// retry_wait_min's DefaultFunc in backend.go), so an explicit 0 here
+	// means the user asked to retry immediately; it must not be coerced
+	// back up to a default.
+	wait := c.retryWaitMin
+
+	for attempt := 0; ; attempt++ {
+		// If we have data we need a reader. Rebuilt on every attempt since
+		// the previous attempt's reader, if any, was already consumed.
+		var reader io.Reader
+		if data != nil {
+			reader = bytes.NewReader(*data)
+		}
+
+		// Create the request
+		req, err := http.NewRequest(method, url, reader)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to make %s HTTP request: %s", what, err)
+		}
+
+		// Setup basic auth
+		if c.username != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+
+		// Allow other auth mechanisms (bearer token, static headers, forward
+		// auth) to decorate the request.
+		if c.decorateRequest != nil {
+			if err := c.decorateRequest(req); err != nil {
+				return nil, fmt.Errorf("Failed to %s: %s", what, err)
+			}
+		}
+
+		for k, v := range extraHeaders {
+			req.Header.Set(k, v)
+		}
+
+		// Work with data/body
+		if data != nil {
+			req.ContentLength = int64(len(*data))
+		}
+
+		// Send the request
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to %s: %s", what, err)
+		}
+
+		if attempt >= c.maxRetries || !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		retryAfter := retryAfterDuration(resp, wait)
+		if c.retryWaitMax > 0 && retryAfter > c.retryWaitMax {
+			retryAfter = c.retryWaitMax
+		}
+		resp.Body.Close()
+
+		time.Sleep(retryAfter)
+
+		wait *= 2
+		if c.retryWaitMax > 0 && wait > c.retryWaitMax {
+			wait = c.retryWaitMax
+		}
+	}
+}
+
+func (c *RemoteClient) Get() (*remote.Payload, error) {
+	resp, err := c.httpRequest("GET", c.address, nil, "get state")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Good
+	case http.StatusNoContent:
+		return nil, nil
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if _, err := io.Copy(buf, resp.Body); err != nil {
+		return nil, fmt.Errorf("Failed to read remote state: %s", err)
+	}
+
+	if c.enableOptimisticLocking {
+		c.etag = resp.Header.Get("ETag")
+	}
+
+	payload := &remote.Payload{
+		Data: buf.Bytes(),
+	}
+
+	if len(payload.Data) == 0 {
+		return nil, nil
+	}
+
+	hash := md5.Sum(payload.Data)
+	payload.MD5 = hash[:]
+
+	return payload, nil
+}
+
+func (c *RemoteClient) Put(data []byte) error {
+	method := "POST"
+	if c.updateMethod != "" {
+		method = c.updateMethod
+	}
+
+	var headers map[string]string
+	if c.enableOptimisticLocking && c.etag != "" {
+		headers = map[string]string{"If-Match": c.etag}
+	}
+
+	resp, err := c.httpRequestWithHeaders(method, c.address, &data, "upload state", headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusPreconditionFailed:
+		return ErrStateChangedConcurrently
+	default:
+		return fmt.Errorf("Failed to upload state: %s", resp.Status)
+	}
+}
+
+func (c *RemoteClient) Delete() error {
+	resp, err := c.httpRequest("DELETE", c.address, nil, "delete state")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("Failed to delete state: %s", resp.Status)
+	}
+}
+
+func (c *RemoteClient) Lock(info *state.LockInfo) (string, error) {
+	if c.lockAddress == "" {
+		return "", nil
+	}
+
+	jsonLockInfo := info.Marshal()
+	resp, err := c.httpRequest(c.lockMethod, c.lockAddress, &jsonLockInfo, "lock")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		c.jsonLockInfo = jsonLockInfo
+		return info.ID, nil
+	case http.StatusUnauthorized:
+		return "", fmt.Errorf("HTTP remote state endpoint requires auth")
+	case http.StatusForbidden:
+		return "", fmt.Errorf("HTTP remote state endpoint invalid auth")
+	case http.StatusConflict, http.StatusLocked:
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("HTTP remote state already locked, failed to read body")
+		}
+		existing := state.LockInfo{}
+		err = json.Unmarshal(body, &existing)
+		if err != nil {
+			return "", fmt.Errorf("HTTP remote state already locked, failed to unmarshal body")
+		}
+		return "", fmt.Errorf("HTTP remote state already locked: ID=%s", existing.ID)
+	default:
+		return "", fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+	}
+}
+
+func (c *RemoteClient) Unlock(id string) error {
+	if c.unlockAddress == "" {
+		return nil
+	}
+
+	resp, err := c.httpRequest(c.unlockMethod, c.unlockAddress, &c.jsonLockInfo, "unlock")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	default:
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+		}
+		return fmt.Errorf("Unexpected HTTP response code %d: %s", resp.StatusCode, string(body))
+	}
+}
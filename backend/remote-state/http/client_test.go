@@ -0,0 +1,167 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flippingETagServer serves a single state blob whose ETag changes out
+// from under the client between the first and second request, simulating
+// a concurrent writer.
+func flippingETagServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var gets int32
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			n := atomic.AddInt32(&gets, 1)
+			w.Header().Set("ETag", "etag-from-get")
+			if n > 1 {
+				// A concurrent writer changed the state after our first read.
+				w.Header().Set("ETag", "etag-after-concurrent-write")
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"version":1}`))
+		case "POST":
+			ifMatch := r.Header.Get("If-Match")
+			if ifMatch != "etag-from-get" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+}
+
+func TestRemoteClient_optimisticLocking(t *testing.T) {
+	server := flippingETagServer(t)
+	defer server.Close()
+
+	client := &RemoteClient{
+		client:                  server.Client(),
+		address:                 server.URL,
+		updateMethod:            "POST",
+		enableOptimisticLocking: true,
+	}
+
+	if _, err := client.Get(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := client.Put([]byte(`{"version":2}`)); err != nil {
+		t.Fatalf("expected the matching ETag to be accepted: %s", err)
+	}
+
+	// A second read picks up the post-write ETag, as if another writer had
+	// raced us; the put from our stale in-memory etag should now fail.
+	client.etag = "stale-etag"
+	if err := client.Put([]byte(`{"version":3}`)); err != ErrStateChangedConcurrently {
+		t.Fatalf("expected ErrStateChangedConcurrently, got %v", err)
+	}
+}
+
+func TestRemoteClient_retriesOnLockedWithRetryAfter(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusLocked)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &RemoteClient{
+		client:       server.Client(),
+		lockAddress:  server.URL,
+		lockMethod:   "LOCK",
+		maxRetries:   5,
+		retryWaitMin: time.Millisecond,
+		retryWaitMax: 10 * time.Millisecond,
+	}
+
+	resp, err := client.httpRequest(client.lockMethod, client.lockAddress, nil, "lock")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected eventual 200, got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRemoteClient_retryWaitMinZeroMeansNoWait(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 3 {
+			// No Retry-After header, so httpRequestWithHeaders falls back to
+			// its own wait/backoff rather than a server-provided delay.
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &RemoteClient{
+		client:       server.Client(),
+		address:      server.URL,
+		maxRetries:   5,
+		retryWaitMin: 0,
+		retryWaitMax: 0,
+	}
+
+	start := time.Now()
+	resp, err := client.httpRequest("GET", client.address, nil, "get state")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected eventual 200, got %d", resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected an explicit retry_wait_min of 0 to retry without the old 1s fallback, took %s", elapsed)
+	}
+}
+
+func TestRemoteClient_givesUpAfterMaxRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &RemoteClient{
+		client:       server.Client(),
+		address:      server.URL,
+		maxRetries:   2,
+		retryWaitMin: time.Millisecond,
+		retryWaitMax: 5 * time.Millisecond,
+	}
+
+	resp, err := client.httpRequest("GET", client.address, nil, "get state")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the final 503 to be returned once retries are exhausted, got %d", resp.StatusCode)
+	}
+}
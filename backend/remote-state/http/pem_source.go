@@ -0,0 +1,45 @@
+package http
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// pemSource is the resolved result of either a *_file or sibling *_pem
+// schema field: the literal PEM bytes to use, plus the credentialSource
+// the transport cache should key on.
+type pemSource struct {
+	data []byte
+	cred credentialSource
+}
+
+// resolvePEMSource reads fileField/pemField (e.g. "local_ca_file" and
+// "local_ca_pem"), enforcing that they're mutually exclusive, following
+// k8s' transport.Config pattern of accepting either a file path or raw
+// PEM bytes. It returns a zero pemSource if neither field is set.
+func resolvePEMSource(data *schema.ResourceData, fileField, pemField string) (pemSource, error) {
+	fileVal, hasFile := data.GetOk(fileField)
+	pemVal, hasPEM := data.GetOk(pemField)
+
+	if hasFile && hasPEM {
+		return pemSource{}, fmt.Errorf("%s and %s are mutually exclusive", fileField, pemField)
+	}
+
+	if hasPEM {
+		content := pemVal.(string)
+		return pemSource{data: []byte(content), cred: credentialSource{pem: content}}, nil
+	}
+
+	if hasFile {
+		path := fileVal.(string)
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return pemSource{}, fmt.Errorf("Failed to read %s into memory: %s", path, err)
+		}
+		return pemSource{data: content, cred: credentialSource{path: path}}, nil
+	}
+
+	return pemSource{}, nil
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func testResourceData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	return schema.TestResourceDataRaw(t, testBackendSchema(), raw)
+}
+
+func TestResolvePEMSource_file(t *testing.T) {
+	caFile, err := ioutil.TempFile("", "http-backend-ca")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(caFile.Name())
+
+	if _, err := caFile.WriteString("file-contents"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	caFile.Close()
+
+	data := testResourceData(t, map[string]interface{}{
+		"address":            "https://example.com/state",
+		"local_cert_ca_file": caFile.Name(),
+	})
+
+	source, err := resolvePEMSource(data, "local_cert_ca_file", "local_cert_ca_pem")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if string(source.data) != "file-contents" {
+		t.Fatalf("expected the file's contents, got %q", source.data)
+	}
+	if source.cred.path != caFile.Name() {
+		t.Fatalf("expected the credential source to track the file path, got %q", source.cred.path)
+	}
+}
+
+func TestResolvePEMSource_inline(t *testing.T) {
+	data := testResourceData(t, map[string]interface{}{
+		"address":           "https://example.com/state",
+		"local_cert_ca_pem": "inline-contents",
+	})
+
+	source, err := resolvePEMSource(data, "local_cert_ca_file", "local_cert_ca_pem")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if string(source.data) != "inline-contents" {
+		t.Fatalf("expected the literal PEM string, got %q", source.data)
+	}
+	if source.cred.pem != "inline-contents" {
+		t.Fatalf("expected the credential source to track the literal PEM, got %q", source.cred.pem)
+	}
+}
+
+func TestResolvePEMSource_mutuallyExclusive(t *testing.T) {
+	data := testResourceData(t, map[string]interface{}{
+		"address":            "https://example.com/state",
+		"local_cert_ca_file": "/tmp/does-not-matter",
+		"local_cert_ca_pem":  "inline-contents",
+	})
+
+	if _, err := resolvePEMSource(data, "local_cert_ca_file", "local_cert_ca_pem"); err == nil {
+		t.Fatal("expected an error when both the file and pem fields are set")
+	}
+}
+
+func TestResolvePEMSource_envDefault(t *testing.T) {
+	os.Setenv("TF_HTTP_LOCAL_CERT_CA_PEM", "env-contents")
+	defer os.Unsetenv("TF_HTTP_LOCAL_CERT_CA_PEM")
+
+	data := testResourceData(t, map[string]interface{}{
+		"address": "https://example.com/state",
+	})
+
+	source, err := resolvePEMSource(data, "local_cert_ca_file", "local_cert_ca_pem")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if string(source.data) != "env-contents" {
+		t.Fatalf("expected local_cert_ca_pem to fall back to TF_HTTP_LOCAL_CERT_CA_PEM, got %q", source.data)
+	}
+}
+
+func TestNewTransportCacheKey_distinguishesInlinePEM(t *testing.T) {
+	b := &Backend{}
+	data := testResourceData(t, map[string]interface{}{
+		"address": "https://example.com/state",
+	})
+
+	key1 := b.newTransportCacheKey(data, "ca", credentialSource{pem: "cert-one"}, credentialSource{}, credentialSource{})
+	key2 := b.newTransportCacheKey(data, "ca", credentialSource{pem: "cert-two"}, credentialSource{}, credentialSource{})
+
+	if key1 == key2 {
+		t.Fatal("expected different inline PEM content to produce different cache keys")
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"golang.org/x/crypto/pkcs12"
+)
+
+// loadPKCS12Bundle decodes a PKCS#12 bundle into the same shape the mutual
+// TLS paths build from separate PEM files: a client certificate/key pair
+// for tls.Certificates, and a pool of any chain certificates found in the
+// bundle to use as RootCAs.
+func loadPKCS12Bundle(pfxData []byte, password string) (tls.Certificate, *x509.CertPool, error) {
+	blocks, err := pkcs12.ToPEM(pfxData, password)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to decode pkcs12 bundle: %s", err)
+	}
+
+	var certPEM, keyPEM bytes.Buffer
+	rootCAs, _ := x509.SystemCertPool()
+	if rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	for _, block := range blocks {
+		switch block.Type {
+		case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+			if err := pem.Encode(&keyPEM, block); err != nil {
+				return tls.Certificate{}, nil, fmt.Errorf("failed to encode pkcs12 private key: %s", err)
+			}
+		case "CERTIFICATE":
+			if certPEM.Len() == 0 {
+				if err := pem.Encode(&certPEM, block); err != nil {
+					return tls.Certificate{}, nil, fmt.Errorf("failed to encode pkcs12 certificate: %s", err)
+				}
+				continue
+			}
+			// Any certificate beyond the first is a chain cert; treat it
+			// as an additional CA rather than part of the leaf identity.
+			var chainPEM bytes.Buffer
+			if err := pem.Encode(&chainPEM, block); err != nil {
+				return tls.Certificate{}, nil, fmt.Errorf("failed to encode pkcs12 chain certificate: %s", err)
+			}
+			if ok := rootCAs.AppendCertsFromPEM(chainPEM.Bytes()); !ok {
+				return tls.Certificate{}, nil, fmt.Errorf("no chain certs could be appended from pkcs12 bundle")
+			}
+		}
+	}
+
+	if certPEM.Len() == 0 || keyPEM.Len() == 0 {
+		return tls.Certificate{}, nil, fmt.Errorf("pkcs12 bundle did not contain both a certificate and a private key")
+	}
+
+	certs, err := tls.X509KeyPair(certPEM.Bytes(), keyPEM.Bytes())
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to build key pair from pkcs12 bundle: %s", err)
+	}
+
+	return certs, rootCAs, nil
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"testing"
+)
+
+func TestBackend_pkcs12MutuallyExclusiveWithPEMFiles(t *testing.T) {
+	b := New()
+	conf := backendTestConfig(t, map[string]interface{}{
+		"address":                   "https://example.com/state",
+		"mutual_tls_authentication": true,
+		"local_pkcs12_file":         testPKCS12Bundle,
+		"local_pkcs12_password":     testPKCS12Password,
+		"local_cert_file":           "/tmp/does-not-matter",
+	})
+
+	if err := b.Configure(conf); err == nil {
+		t.Fatal("expected an error when local_pkcs12_file and local_cert_file are both set")
+	}
+}
+
+func TestBackend_pkcs12MutuallyExclusiveWithInlinePEM(t *testing.T) {
+	b := New()
+	conf := backendTestConfig(t, map[string]interface{}{
+		"address":                   "https://example.com/state",
+		"mutual_tls_authentication": true,
+		"local_pkcs12_file":         testPKCS12Bundle,
+		"local_pkcs12_password":     testPKCS12Password,
+		"local_key_pem":             "inline-key",
+	})
+
+	if err := b.Configure(conf); err == nil {
+		t.Fatal("expected an error when local_pkcs12_file and local_key_pem are both set")
+	}
+}
+
+func TestBackend_pkcs12PasswordMutuallyExclusive(t *testing.T) {
+	b := New()
+	conf := backendTestConfig(t, map[string]interface{}{
+		"address":                    "https://example.com/state",
+		"mutual_tls_authentication":  true,
+		"local_pkcs12_file":          testPKCS12Bundle,
+		"local_pkcs12_password":      testPKCS12Password,
+		"local_pkcs12_password_file": "/tmp/does-not-matter",
+	})
+
+	if err := b.Configure(conf); err == nil {
+		t.Fatal("expected an error when local_pkcs12_password and local_pkcs12_password_file are both set")
+	}
+}
+
+func TestBackend_pkcs12Succeeds(t *testing.T) {
+	b := New()
+	conf := backendTestConfig(t, map[string]interface{}{
+		"address":                   "https://example.com/state",
+		"mutual_tls_authentication": true,
+		"local_pkcs12_file":         testPKCS12Bundle,
+		"local_pkcs12_password":     testPKCS12Password,
+	})
+
+	if err := b.Configure(conf); err != nil {
+		t.Fatalf("expected the pkcs12 bundle to configure successfully, got: %s", err)
+	}
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"bytes"
+	"crypto/x509"
+	"io/ioutil"
+	"testing"
+
+	"golang.org/x/crypto/pkcs12"
+)
+
+const testPKCS12Bundle = "testdata/bundle.p12"
+const testPKCS12Password = "test1234"
+
+// testPKCS12ChainCertSubject independently decodes the bundle's chain cert
+// (test-ca) without going through loadPKCS12Bundle, so the assertion in
+// TestLoadPKCS12Bundle actually exercises chain-cert handling instead of
+// passing vacuously because the system cert pool is non-empty.
+func testPKCS12ChainCertSubject(t *testing.T) []byte {
+	t.Helper()
+
+	pfxData, err := ioutil.ReadFile(testPKCS12Bundle)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	blocks, err := pkcs12.ToPEM(pfxData, testPKCS12Password)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var seenLeaf bool
+	for _, block := range blocks {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if !seenLeaf {
+			seenLeaf = true
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		return cert.RawSubject
+	}
+
+	t.Fatal("expected the bundle to contain a chain cert beyond the leaf")
+	return nil
+}
+
+func TestLoadPKCS12Bundle(t *testing.T) {
+	chainCertSubject := testPKCS12ChainCertSubject(t)
+
+	pfxData, err := ioutil.ReadFile(testPKCS12Bundle)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	certs, rootCAs, err := loadPKCS12Bundle(pfxData, testPKCS12Password)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(certs.Certificate) == 0 {
+		t.Fatal("expected a leaf certificate to be decoded from the bundle")
+	}
+	if certs.PrivateKey == nil {
+		t.Fatal("expected a private key to be decoded from the bundle")
+	}
+	if rootCAs == nil {
+		t.Fatal("expected a non-nil CA pool")
+	}
+
+	var found bool
+	for _, subject := range rootCAs.Subjects() {
+		if bytes.Equal(subject, chainCertSubject) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatal("expected the bundle's chain cert subject to be appended to the CA pool")
+	}
+}
+
+func TestLoadPKCS12Bundle_badPassword(t *testing.T) {
+	pfxData, err := ioutil.ReadFile(testPKCS12Bundle)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, _, err := loadPKCS12Bundle(pfxData, "wrong-password"); err == nil {
+		t.Fatal("expected an error decoding the bundle with the wrong password")
+	}
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// isRetryableStatus reports whether resp's status code is one the backend
+// should retry with backoff: the lock/unlock/state endpoints use 423 for
+// "already locked" and 429/503 for rate limiting or transient overload.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusLocked, http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfterDuration reads the Retry-After header (either delay-seconds or
+// an HTTP-date, per RFC 7231), falling back to wait if the header is
+// absent or unparseable.
+func retryAfterDuration(resp *http.Response, wait time.Duration) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return wait
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+
+	if when, err := http.ParseTime(v); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+
+	return wait
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var tlsVersionsByName = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+func tlsVersionFromName(name string) (uint16, error) {
+	v, ok := tlsVersionsByName[name]
+	if !ok {
+		return 0, fmt.Errorf("unsupported TLS version %q: must be one of \"1.0\", \"1.1\", \"1.2\", \"1.3\"", name)
+	}
+	return v, nil
+}
+
+func tlsCipherSuiteFromName(name string) (uint16, error) {
+	for _, c := range tls.CipherSuites() {
+		if c.Name == name {
+			return c.ID, nil
+		}
+	}
+	for _, c := range tls.InsecureCipherSuites() {
+		if c.Name == name {
+			return c.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown tls_cipher_suites entry %q", name)
+}
+
+// baseTLSConfig builds the version/cipher/SNI settings shared by every
+// tls.Config the backend constructs, so the skip-verify, CA-only, and
+// mTLS paths in configure() only need to layer their own
+// InsecureSkipVerify/RootCAs/Certificates on top.
+func baseTLSConfig(data *schema.ResourceData) (*tls.Config, error) {
+	cfg := &tls.Config{}
+
+	if v, ok := data.GetOk("tls_min_version"); ok {
+		version, err := tlsVersionFromName(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls_min_version: %s", err)
+		}
+		cfg.MinVersion = version
+	}
+
+	if v, ok := data.GetOk("tls_max_version"); ok {
+		version, err := tlsVersionFromName(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls_max_version: %s", err)
+		}
+		cfg.MaxVersion = version
+	}
+
+	if v, ok := data.GetOk("tls_cipher_suites"); ok {
+		names := v.([]interface{})
+		suites := make([]uint16, 0, len(names))
+		for _, n := range names {
+			suite, err := tlsCipherSuiteFromName(n.(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid tls_cipher_suites: %s", err)
+			}
+			suites = append(suites, suite)
+		}
+		cfg.CipherSuites = suites
+	}
+
+	if v, ok := data.GetOk("tls_server_name"); ok {
+		cfg.ServerName = v.(string)
+	}
+
+	if v, ok := data.GetOk("tls_prefer_server_cipher_suites"); ok {
+		cfg.PreferServerCipherSuites = v.(bool)
+	}
+
+	return cfg, nil
+}
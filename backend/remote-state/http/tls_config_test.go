@@ -0,0 +1,35 @@
+package http
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestTLSVersionFromName(t *testing.T) {
+	v, err := tlsVersionFromName("1.2")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v != tls.VersionTLS12 {
+		t.Fatalf("expected VersionTLS12, got %d", v)
+	}
+
+	if _, err := tlsVersionFromName("2.0"); err == nil {
+		t.Fatal("expected an error for an unsupported TLS version")
+	}
+}
+
+func TestTLSCipherSuiteFromName(t *testing.T) {
+	want := tls.CipherSuites()[0]
+	got, err := tlsCipherSuiteFromName(want.Name)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got != want.ID {
+		t.Fatalf("expected cipher suite %d, got %d", want.ID, got)
+	}
+
+	if _, err := tlsCipherSuiteFromName("not_a_real_cipher_suite"); err == nil {
+		t.Fatal("expected an error for an unknown cipher suite name")
+	}
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// credentialSource identifies where a cert/key/CA came from: a file on
+// disk, in which case the cache watches its mtime, or literal PEM bytes
+// passed inline, in which case the content itself (hashed) is the
+// identity and there is no mtime to watch.
+type credentialSource struct {
+	path string
+	pem  string
+}
+
+func (c credentialSource) cacheIdentity() (id string, modTime time.Time) {
+	switch {
+	case c.path != "":
+		return "file:" + c.path, fileModTime(c.path)
+	case c.pem != "":
+		sum := sha256.Sum256([]byte(c.pem))
+		return "pem:" + hex.EncodeToString(sum[:]), time.Time{}
+	default:
+		return "", time.Time{}
+	}
+}
+
+// transportCacheKey captures the TLS-relevant subset of backend
+// configuration that determines the shape of a *http.Transport: the CA,
+// client cert, and client key material (by identity and, for file-backed
+// credentials, mtime, so a rotated credential busts the cache), the
+// skip-verify flag, the negotiated version/cipher settings, and the
+// transport-level timeouts. Repeated `terraform init`/`plan` cycles and
+// multi-workspace usage with identical settings share the same entry
+// instead of each opening a fresh connection pool.
+type transportCacheKey struct {
+	mode                     string
+	caID                     string
+	caModTime                time.Time
+	certID                   string
+	certModTime              time.Time
+	keyID                    string
+	keyModTime               time.Time
+	skipTLS                  bool
+	minVersion               uint16
+	maxVersion               uint16
+	cipherSuites             string
+	serverName               string
+	preferServerCipherSuites bool
+	tlsHandshakeTimeout      time.Duration
+	idleConnTimeout          time.Duration
+}
+
+// identity returns key with the mtime fields zeroed, i.e. the subset of the
+// key that identifies *which* credential/config this transport was built
+// for, independent of when a file-backed credential was last rotated. Two
+// keys with the same identity but different mtimes are successive
+// generations of the same configuration, not unrelated configurations that
+// happen to collide.
+func (k transportCacheKey) identity() transportCacheKey {
+	k.caModTime = time.Time{}
+	k.certModTime = time.Time{}
+	k.keyModTime = time.Time{}
+	return k
+}
+
+var (
+	transportCacheMu sync.Mutex
+	transportCache   = map[transportCacheKey]*http.Transport{}
+	// transportCacheGen tracks the most recent full key seen for each
+	// identity(), so cachedTransport can evict the prior generation's
+	// transport (and its pooled idle connections) once a file-backed
+	// credential rotates, instead of accumulating one entry per mtime seen.
+	transportCacheGen = map[transportCacheKey]transportCacheKey{}
+)
+
+// resetTransports clears the package-level transport cache. It is exposed
+// on Backend so tests can assert on cache hits/misses without leaking
+// state between cases.
+func (b *Backend) resetTransports() {
+	transportCacheMu.Lock()
+	defer transportCacheMu.Unlock()
+	transportCache = map[transportCacheKey]*http.Transport{}
+	transportCacheGen = map[transportCacheKey]transportCacheKey{}
+}
+
+func fileModTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// newTransportCacheKey builds the cache key for the current configuration.
+// mode distinguishes the skip-verify, CA-only, and mTLS (PEM or pkcs12)
+// configure() paths, since they produce differently shaped transports even
+// when the version/cipher settings match.
+func (b *Backend) newTransportCacheKey(data *schema.ResourceData, mode string, ca, cert, key credentialSource) transportCacheKey {
+	var cipherSuites string
+	if v, ok := data.GetOk("tls_cipher_suites"); ok {
+		for _, c := range v.([]interface{}) {
+			cipherSuites += c.(string) + ","
+		}
+	}
+
+	var minVersion, maxVersion uint16
+	if v, ok := data.GetOk("tls_min_version"); ok {
+		minVersion, _ = tlsVersionFromName(v.(string))
+	}
+	if v, ok := data.GetOk("tls_max_version"); ok {
+		maxVersion, _ = tlsVersionFromName(v.(string))
+	}
+
+	caID, caModTime := ca.cacheIdentity()
+	certID, certModTime := cert.cacheIdentity()
+	keyID, keyModTime := key.cacheIdentity()
+
+	return transportCacheKey{
+		mode:                     mode,
+		caID:                     caID,
+		caModTime:                caModTime,
+		certID:                   certID,
+		certModTime:              certModTime,
+		keyID:                    keyID,
+		keyModTime:               keyModTime,
+		skipTLS:                  data.Get("skip_cert_verification").(bool),
+		minVersion:               minVersion,
+		maxVersion:               maxVersion,
+		cipherSuites:             cipherSuites,
+		serverName:               data.Get("tls_server_name").(string),
+		preferServerCipherSuites: data.Get("tls_prefer_server_cipher_suites").(bool),
+		tlsHandshakeTimeout:      b.tlsHandshakeTimeout,
+		idleConnTimeout:          b.idleConnTimeout,
+	}
+}
+
+// cachedTransport returns the pooled *http.Transport for key, building one
+// from tlsConfig and the backend's configured timeouts if this is the
+// first time it has been seen.
+func (b *Backend) cachedTransport(key transportCacheKey, tlsConfig *tls.Config) *http.Transport {
+	transportCacheMu.Lock()
+	defer transportCacheMu.Unlock()
+
+	if transport, ok := transportCache[key]; ok {
+		return transport
+	}
+
+	identity := key.identity()
+	if prevKey, ok := transportCacheGen[identity]; ok {
+		if prevTransport, ok := transportCache[prevKey]; ok {
+			prevTransport.CloseIdleConnections()
+			delete(transportCache, prevKey)
+		}
+	}
+	transportCacheGen[identity] = key
+
+	transport := &http.Transport{
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: b.tlsHandshakeTimeout,
+		IdleConnTimeout:     b.idleConnTimeout,
+	}
+	transportCache[key] = transport
+	return transport
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func testBackendSchema() map[string]*schema.Schema {
+	return New().(*Backend).Backend.Schema
+}
+
+func TestCachedTransport_reusesEntry(t *testing.T) {
+	b := &Backend{}
+	b.resetTransports()
+	defer b.resetTransports()
+
+	key := transportCacheKey{mode: "ca", caID: "file:/tmp/does-not-matter"}
+
+	first := b.cachedTransport(key, &tls.Config{})
+	second := b.cachedTransport(key, &tls.Config{})
+
+	if first != second {
+		t.Fatal("expected the same *http.Transport to be returned for an identical cache key")
+	}
+}
+
+func TestCachedTransport_invalidatesOnCertFileChange(t *testing.T) {
+	b := &Backend{}
+	b.resetTransports()
+	defer b.resetTransports()
+
+	certFile, err := ioutil.TempFile("", "http-backend-cert")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(certFile.Name())
+	certFile.Close()
+
+	data := schema.TestResourceDataRaw(t, testBackendSchema(), map[string]interface{}{
+		"address": "https://example.com/state",
+	})
+
+	key1 := b.newTransportCacheKey(data, "mtls-pem", credentialSource{}, credentialSource{path: certFile.Name()}, credentialSource{})
+	first := b.cachedTransport(key1, &tls.Config{})
+
+	// Touch the cert file so its mtime changes, as if it were rotated.
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(certFile.Name(), later, later); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	key2 := b.newTransportCacheKey(data, "mtls-pem", credentialSource{}, credentialSource{path: certFile.Name()}, credentialSource{})
+	second := b.cachedTransport(key2, &tls.Config{})
+
+	if first == second {
+		t.Fatal("expected a changed cert file mtime to invalidate the cached transport")
+	}
+}
+
+func TestCachedTransport_evictsPriorGenerationOnRotation(t *testing.T) {
+	b := &Backend{}
+	b.resetTransports()
+	defer b.resetTransports()
+
+	certFile, err := ioutil.TempFile("", "http-backend-cert")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(certFile.Name())
+	certFile.Close()
+
+	data := schema.TestResourceDataRaw(t, testBackendSchema(), map[string]interface{}{
+		"address": "https://example.com/state",
+	})
+
+	key1 := b.newTransportCacheKey(data, "mtls-pem", credentialSource{}, credentialSource{path: certFile.Name()}, credentialSource{})
+	b.cachedTransport(key1, &tls.Config{})
+
+	if len(transportCache) != 1 {
+		t.Fatalf("expected 1 cached transport after the first build, got %d", len(transportCache))
+	}
+
+	// Touch the cert file so its mtime changes, as if it were rotated.
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(certFile.Name(), later, later); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	key2 := b.newTransportCacheKey(data, "mtls-pem", credentialSource{}, credentialSource{path: certFile.Name()}, credentialSource{})
+	b.cachedTransport(key2, &tls.Config{})
+
+	if len(transportCache) != 1 {
+		t.Fatalf("expected the prior generation's transport to be evicted, leaving 1 entry, got %d", len(transportCache))
+	}
+	if _, ok := transportCache[key1]; ok {
+		t.Fatal("expected the stale pre-rotation cache entry to have been removed")
+	}
+}